@@ -0,0 +1,27 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crds embeds the CustomResourceDefinition schemas this adapter
+// ships, so Go code can apply them to a cluster without relying on a
+// separate install step.
+package crds
+
+import _ "embed"
+
+// NighthawkAdapterConfig is the raw YAML of
+// build/crds/nighthawkadapterconfig.yaml, the CRD nighthawk.ConfigController
+// watches.
+//
+//go:embed nighthawkadapterconfig.yaml
+var NighthawkAdapterConfig []byte
@@ -0,0 +1,145 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentials loads the Meshery Credential used to authenticate
+// outbound calls this adapter makes to Meshery Server, such as component
+// registration.
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	configprovider "github.com/layer5io/meshkit/config/provider"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Type identifies the shape of a Credential's Secret map, matching
+// Meshery's credential schema.
+type Type string
+
+const (
+	TypeBearerToken Type = "bearer-token"
+	TypeBasicAuth   Type = "basic-auth"
+	TypeMTLS        Type = "mtls"
+)
+
+// Credential is a type + secret-map pair matching the credential schema
+// Meshery Server stores for this adapter's outbound calls.
+type Credential struct {
+	Type   Type              `json:"type"`
+	Secret map[string]string `json:"secret"`
+}
+
+// configCredentialKey is where the credential is stored when loaded
+// through the adapter's own config provider.
+const configCredentialKey = "credential"
+
+// FromFile loads a Credential from a JSON file, as referenced by the
+// MESHERY_CREDENTIAL_FILE env var.
+func FromFile(path string) (*Credential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrLoadCredential(err)
+	}
+
+	return unmarshal(data)
+}
+
+// FromKubernetesSecret loads a Credential from a Kubernetes Secret,
+// expecting the same JSON document FromFile reads under the "credential"
+// key.
+func FromKubernetesSecret(ctx context.Context, client kubernetes.Interface, namespace, name string) (*Credential, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, ErrLoadCredential(err)
+	}
+
+	data, ok := secret.Data["credential"]
+	if !ok {
+		return nil, ErrMalformedCredential(nil)
+	}
+
+	return unmarshal(data)
+}
+
+// FromKubernetesSecretUsingKubeconfig builds a Kubernetes clientset from
+// kubeconfig bytes and loads the Credential from the named Secret, for
+// callers that only have the adapter's kubeconfig on hand rather than an
+// already-built client.
+func FromKubernetesSecretUsingKubeconfig(ctx context.Context, kubeconfig []byte, namespace, name string) (*Credential, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, ErrLoadCredential(err)
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, ErrLoadCredential(err)
+	}
+
+	return FromKubernetesSecret(ctx, client, namespace, name)
+}
+
+// FromConfig loads a Credential from the adapter's own config provider,
+// the same one config.New(configprovider.ViperKey) returns.
+func FromConfig(cfg configprovider.Handler) (*Credential, error) {
+	var cred Credential
+	if err := cfg.GetObject(configCredentialKey, &cred); err != nil {
+		return nil, ErrLoadCredential(err)
+	}
+
+	if err := cred.validate(); err != nil {
+		return nil, err
+	}
+
+	return &cred, nil
+}
+
+func unmarshal(data []byte) (*Credential, error) {
+	var cred Credential
+	if err := json.Unmarshal(data, &cred); err != nil {
+		return nil, ErrMalformedCredential(err)
+	}
+
+	if err := cred.validate(); err != nil {
+		return nil, err
+	}
+
+	return &cred, nil
+}
+
+func (c Credential) validate() error {
+	switch c.Type {
+	case TypeBearerToken:
+		if c.Secret["token"] == "" {
+			return ErrMalformedCredential(nil)
+		}
+	case TypeBasicAuth:
+		if c.Secret["username"] == "" || c.Secret["password"] == "" {
+			return ErrMalformedCredential(nil)
+		}
+	case TypeMTLS:
+		if c.Secret["certFile"] == "" || c.Secret["keyFile"] == "" {
+			return ErrMalformedCredential(nil)
+		}
+	default:
+		return ErrUnsupportedCredentialType(string(c.Type))
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credential.json")
+	if err := os.WriteFile(path, []byte(`{"type":"bearer-token","secret":{"token":"s3cr3t"}}`), 0600); err != nil {
+		t.Fatalf("failed to write test fixture: %v", err)
+	}
+
+	cred, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if cred.Type != TypeBearerToken || cred.Secret["token"] != "s3cr3t" {
+		t.Fatalf("FromFile() = %+v, want bearer-token/s3cr3t", cred)
+	}
+}
+
+func TestFromFileMissing(t *testing.T) {
+	if _, err := FromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("FromFile() error = nil, want error for missing file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cred    Credential
+		wantErr bool
+	}{
+		{
+			name: "valid bearer token",
+			cred: Credential{Type: TypeBearerToken, Secret: map[string]string{"token": "t"}},
+		},
+		{
+			name:    "bearer token missing token",
+			cred:    Credential{Type: TypeBearerToken, Secret: map[string]string{}},
+			wantErr: true,
+		},
+		{
+			name: "valid basic auth",
+			cred: Credential{Type: TypeBasicAuth, Secret: map[string]string{"username": "u", "password": "p"}},
+		},
+		{
+			name:    "basic auth missing password",
+			cred:    Credential{Type: TypeBasicAuth, Secret: map[string]string{"username": "u"}},
+			wantErr: true,
+		},
+		{
+			name: "valid mtls",
+			cred: Credential{Type: TypeMTLS, Secret: map[string]string{"certFile": "c", "keyFile": "k"}},
+		},
+		{
+			name:    "mtls missing keyFile",
+			cred:    Credential{Type: TypeMTLS, Secret: map[string]string{"certFile": "c"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			cred:    Credential{Type: Type("oauth2"), Secret: map[string]string{}},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.cred.validate()
+			if c.wantErr && err == nil {
+				t.Fatal("validate() error = nil, want error")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
@@ -0,0 +1,74 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import "github.com/layer5io/meshkit/errors"
+
+const (
+	ErrLoadCredentialCode            = "nighthawk_credentials_1000"
+	ErrMalformedCredentialCode       = "nighthawk_credentials_1001"
+	ErrUnsupportedCredentialTypeCode = "nighthawk_credentials_1002"
+	ErrWatchCredentialCode           = "nighthawk_credentials_1003"
+)
+
+// ErrLoadCredential is returned when the Meshery Credential could not be
+// read from its configured source.
+func ErrLoadCredential(err error) error {
+	desc := "unknown error"
+	if err != nil {
+		desc = err.Error()
+	}
+
+	return errors.New(ErrLoadCredentialCode, errors.Alert,
+		[]string{"Failed to load the Meshery credential used to authenticate with Meshery Server"},
+		[]string{desc},
+		[]string{"MESHERY_CREDENTIAL_FILE is unset or points to a missing file, or the referenced Secret does not exist"},
+		[]string{"Mount a credential file and set MESHERY_CREDENTIAL_FILE, or provide a Kubernetes Secret reference"})
+}
+
+// ErrMalformedCredential is returned when a loaded credential does not
+// match the expected schema for its type.
+func ErrMalformedCredential(err error) error {
+	desc := "missing required secret fields for the declared credential type"
+	if err != nil {
+		desc = err.Error()
+	}
+
+	return errors.New(ErrMalformedCredentialCode, errors.Alert,
+		[]string{"The Meshery credential is malformed"},
+		[]string{desc},
+		[]string{"The credential JSON is missing the secret fields its declared type requires"},
+		[]string{"Ensure the credential has a \"type\" of bearer-token, basic-auth, or mtls and the matching secret fields"})
+}
+
+// ErrUnsupportedCredentialType is returned when a credential declares a
+// type this adapter does not support.
+func ErrUnsupportedCredentialType(credType string) error {
+	return errors.New(ErrUnsupportedCredentialTypeCode, errors.Alert,
+		[]string{"Unsupported Meshery credential type: " + credType},
+		[]string{"only bearer-token, basic-auth, and mtls credentials are supported"},
+		[]string{"The credential was authored for a credential type this adapter does not implement"},
+		[]string{"Use one of bearer-token, basic-auth, or mtls"})
+}
+
+// ErrWatchCredential is returned when the credential file watcher could
+// not be started.
+func ErrWatchCredential(err error) error {
+	return errors.New(ErrWatchCredentialCode, errors.Alert,
+		[]string{"Failed to watch the Meshery credential file for rotation"},
+		[]string{err.Error()},
+		[]string{"The credential file's directory is not watchable, often due to permissions"},
+		[]string{"Ensure the adapter process can read the credential file's parent directory"})
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/layer5io/meshkit/logger"
+)
+
+// WatchFile reloads the credential at path into rt whenever the file
+// changes on disk, so rotated credentials (e.g. a Kubernetes Secret
+// mounted as a file) take effect without restarting the adapter.
+func WatchFile(path string, rt *RoundTripper, log logger.Handler) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, ErrWatchCredential(err)
+	}
+
+	if err := watcher.Add(path); err != nil {
+		_ = watcher.Close()
+		return nil, ErrWatchCredential(err)
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				cred, err := FromFile(path)
+				if err != nil {
+					log.Error(err)
+					continue
+				}
+
+				if err := rt.Set(cred); err != nil {
+					log.Error(err)
+					continue
+				}
+
+				log.Info("Reloaded Meshery credential from ", path)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error(err)
+			}
+		}
+	}()
+
+	return watcher, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package edge runs Nighthawk as a standalone, Meshery-server-less load
+// generator: an HTTP ingress that accepts test definitions and streams
+// results back, for use as an edge/API-gateway-style tool.
+package edge
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/layer5io/meshkit/logger"
+	"github.com/meshery/meshery-nighthawk/internal/config"
+	"github.com/meshery/meshery-nighthawk/nighthawk"
+)
+
+// TestDefinition is the JSON/YAML body the edge ingress accepts.
+type TestDefinition struct {
+	Target          string            `json:"target" yaml:"target"`
+	RPS             int               `json:"rps" yaml:"rps"`
+	Concurrency     int               `json:"concurrency" yaml:"concurrency"`
+	DurationSeconds int               `json:"durationSeconds" yaml:"durationSeconds"`
+	Headers         map[string]string `json:"headers" yaml:"headers"`
+	TLS             *TLSDefinition    `json:"tls" yaml:"tls"`
+}
+
+// TLSDefinition is the TLS portion of a TestDefinition.
+type TLSDefinition struct {
+	InsecureSkipVerify bool   `json:"insecureSkipVerify" yaml:"insecureSkipVerify"`
+	CAFile             string `json:"caFile" yaml:"caFile"`
+	CertFile           string `json:"certFile" yaml:"certFile"`
+	KeyFile            string `json:"keyFile" yaml:"keyFile"`
+}
+
+// Server is the edge-mode HTTP ingress for submitting load tests.
+type Server struct {
+	log logger.Handler
+	mux *http.ServeMux
+}
+
+// NewServer builds the edge ingress, routing test submissions to
+// nighthawk.RunLoadTest.
+func NewServer(log logger.Handler) *Server {
+	s := &Server{log: log, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/tests", s.handleSubmitTest)
+	return s
+}
+
+// ListenAndServe starts the edge HTTP ingress on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	s.log.Info("Edge ingress listening at ", addr)
+	return http.ListenAndServe(addr, s.mux) //nolint:gosec
+}
+
+func (s *Server) handleSubmitTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	def, err := decodeTestDefinition(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	results, err := nighthawk.RunLoadTest(r.Context(), toLoadTestRequest(def))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			s.log.Error(err)
+			continue
+		}
+
+		if _, err := w.Write([]byte("data: " + string(data) + "\n\n")); err != nil {
+			s.log.Warn(err)
+			return
+		}
+		flusher.Flush()
+
+		if result.Done {
+			return
+		}
+	}
+}
+
+// decodeTestDefinition accepts either JSON or YAML test definitions,
+// regardless of Content-Type, via the adapter's unified config loader.
+func decodeTestDefinition(r *http.Request) (TestDefinition, error) {
+	var def TestDefinition
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return def, err
+	}
+
+	err = config.Unmarshal(body, &def)
+	return def, err
+}
+
+func toLoadTestRequest(def TestDefinition) nighthawk.LoadTestRequest {
+	req := nighthawk.LoadTestRequest{
+		Target:      def.Target,
+		RPS:         def.RPS,
+		Concurrency: def.Concurrency,
+		Duration:    time.Duration(def.DurationSeconds) * time.Second,
+		Headers:     def.Headers,
+	}
+
+	if def.TLS != nil {
+		req.TLS = &nighthawk.TLSSettings{
+			InsecureSkipVerify: def.TLS.InsecureSkipVerify,
+			CAFile:             def.TLS.CAFile,
+			CertFile:           def.TLS.CertFile,
+			KeyFile:            def.TLS.KeyFile,
+		}
+	}
+
+	return req
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/layer5io/meshkit/errors"
+)
+
+const ErrUnmarshalConfigCode = "nighthawk_config_2000"
+
+// ErrUnmarshalConfig is returned when a config payload is valid as
+// neither JSON nor YAML. err is the JSON parse error, since it is
+// attempted first.
+func ErrUnmarshalConfig(err error) error {
+	detail := err.Error()
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		detail = fmt.Sprintf("%s (byte offset %d)", err.Error(), syntaxErr.Offset)
+	}
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+		detail = fmt.Sprintf("%s (byte offset %d)", err.Error(), typeErr.Offset)
+	}
+
+	return errors.New(ErrUnmarshalConfigCode, errors.Alert,
+		[]string{"Failed to parse config payload as either JSON or YAML"},
+		[]string{detail},
+		[]string{"The payload has a syntax error or does not match the expected structure"},
+		[]string{"Check the payload against the expected schema and fix the reported offset"})
+}
@@ -0,0 +1,42 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unmarshal decodes data into v, accepting either JSON or YAML so callers
+// don't need to know up front which format a config payload was authored
+// in. It tries JSON first, surfacing syntax/type errors with their byte
+// offset, and falls back to YAML when data isn't valid JSON.
+//
+// Use this for any config payload that arrives as raw bytes rather than
+// through Viper - CRD spec bytes, inline test definitions submitted
+// through the adapter's operations, and component-generation responses.
+func Unmarshal(data []byte, v any) error {
+	jsonErr := json.Unmarshal(data, v)
+	if jsonErr == nil {
+		return nil
+	}
+
+	if yamlErr := yaml.Unmarshal(data, v); yamlErr == nil {
+		return nil
+	}
+
+	return ErrUnmarshalConfig(jsonErr)
+}
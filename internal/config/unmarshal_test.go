@@ -0,0 +1,54 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "testing"
+
+func TestUnmarshalJSON(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	if err := Unmarshal([]byte(`{"name":"nighthawk"}`), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.Name != "nighthawk" {
+		t.Fatalf("Unmarshal() name = %q, want nighthawk", v.Name)
+	}
+}
+
+func TestUnmarshalYAMLFallback(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	if err := Unmarshal([]byte("name: nighthawk\n"), &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.Name != "nighthawk" {
+		t.Fatalf("Unmarshal() name = %q, want nighthawk", v.Name)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+
+	err := Unmarshal([]byte("{not valid json or yaml: ["), &v)
+	if err == nil {
+		t.Fatal("Unmarshal() error = nil, want error for payload that is neither JSON nor YAML")
+	}
+}
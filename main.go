@@ -15,7 +15,11 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -28,11 +32,47 @@ import (
 	"github.com/layer5io/meshkit/logger"
 	"github.com/layer5io/meshkit/utils/events"
 	"github.com/meshery/meshery-nighthawk/build"
+	"github.com/meshery/meshery-nighthawk/credentials"
+	"github.com/meshery/meshery-nighthawk/edge"
 	"github.com/meshery/meshery-nighthawk/internal/config"
 	"github.com/meshery/meshery-nighthawk/nighthawk"
+	"github.com/meshery/meshery-nighthawk/registry"
 	"github.com/sirupsen/logrus"
 )
 
+// maxRegistrationAttempts bounds how many times a failed component is
+// retried before it is left recorded as permanently failed in the ledger.
+// 0 would mean unlimited; workflows polling the dump endpoint need this to
+// eventually stop retrying and report red.
+const maxRegistrationAttempts = 10
+
+// registryDumpAddr is the address the attempt ledger dump endpoint listens
+// on, overridable so it doesn't collide with the adapter's gRPC port.
+func registryDumpAddr() string {
+	if addr := os.Getenv("REGISTRY_DUMP_ADDR"); addr != "" {
+		return addr
+	}
+	return ":9082"
+}
+
+// edgeFlag lets the adapter be started in standalone edge mode with
+// `--edge` in addition to `MODE=edge`.
+var edgeFlag = flag.Bool("edge", false, "run as a standalone edge load generator instead of a Meshery adapter")
+
+// isEdgeMode reports whether the adapter should run as a standalone edge
+// load generator instead of registering with a Meshery Server.
+func isEdgeMode() bool {
+	return *edgeFlag || strings.EqualFold(os.Getenv("MODE"), "edge")
+}
+
+// edgeAddr is the address the edge-mode HTTP ingress listens on.
+func edgeAddr() string {
+	if addr := os.Getenv("EDGE_ADDR"); addr != "" {
+		return addr
+	}
+	return ":8081"
+}
+
 var (
 	serviceName = "meshery-nighthawk"
 	version     = "edge"
@@ -50,6 +90,8 @@ func init() {
 }
 
 func main() {
+	flag.Parse()
+
 	logLevel := logrus.InfoLevel
 	if isDebug() {
 		logLevel = logrus.InfoLevel
@@ -94,6 +136,12 @@ func main() {
 
 	ev := events.NewEventStreamer()
 
+	if !isEdgeMode() {
+		if err := authenticateOutboundCalls(cfg, log); err != nil {
+			log.Warn(err)
+		}
+	}
+
 	// Initialize Handler intance
 	handler := nighthawk.New(cfg, log, kubeconfigHandler, ev)
 	handler = adapter.AddLogger(log, handler)
@@ -103,8 +151,46 @@ func main() {
 	service.StartedAt = time.Now()
 	service.Version = version
 	service.GitSHA = gitsha
-	go registerCapabilities(service.Port, log)            //Registering static capabilities
-	go registerCapabilitiesDynamically(service.Port, log) //Registering latest capabilities periodically
+
+	// Start watching NighthawkAdapterConfig in both modes and register it
+	// with the nighthawk package so RunLoadTest - used by the edge ingress
+	// and the gRPC-driven load-test handler alike - picks up new defaults
+	// and target allow/deny lists without a restart.
+	if kubeconfig, err := readKubeconfig(); err != nil {
+		log.Warn(err)
+	} else {
+		configController, err := nighthawk.NewConfigController(kubeconfig, ev, log)
+		if err != nil {
+			log.Error(err)
+		} else {
+			nighthawk.SetConfigController(configController)
+			stopConfigWatch := make(chan struct{})
+			defer close(stopConfigWatch)
+			configController.Start(stopConfigWatch)
+		}
+	}
+
+	if isEdgeMode() {
+		// Edge mode runs without a Meshery Server: skip the static/dynamic
+		// capability registration gating entirely and register an edge
+		// capability set instead.
+		registerEdgeCapabilities(log)
+		go func() {
+			if err := edge.NewServer(log).ListenAndServe(edgeAddr()); err != nil {
+				log.Error(err)
+			}
+		}()
+	} else {
+		tracker, err := registry.NewAttemptTracker(config.RootPath(), maxRegistrationAttempts)
+		if err != nil {
+			log.Error(err)
+			os.Exit(1)
+		}
+
+		go serveRegistryDump(tracker, log, ev)
+		go registerCapabilities(service.Port, log, tracker)            //Registering static capabilities
+		go registerCapabilitiesDynamically(service.Port, log, tracker) //Registering latest capabilities periodically
+	}
 
 	// Server Initialization
 	log.Info("Component listening at port: ", service.Port)
@@ -119,6 +205,74 @@ func isDebug() bool {
 	return os.Getenv("DEBUG") == "true"
 }
 
+// readKubeconfig reads the kubeconfig file the adapter points KUBECONFIG
+// at, for callers (the NighthawkAdapterConfig watcher, Secret-backed
+// credential loading) that need a Kubernetes client built from it.
+func readKubeconfig() ([]byte, error) {
+	return os.ReadFile(path.Join(
+		config.KubeConfig[configprovider.FilePath],
+		fmt.Sprintf("%s.%s", config.KubeConfig[configprovider.FileName], config.KubeConfig[configprovider.FileType])))
+}
+
+// authenticateOutboundCalls loads the Meshery Credential used to register
+// with Meshery Server and installs it as http.DefaultClient's transport,
+// so adapter.RegisterMeshModelComponents and any MESHERY_SERVER callbacks
+// authenticate instead of failing silently once auth is enforced. The
+// credential is loaded from MESHERY_CREDENTIAL_FILE, a Kubernetes Secret
+// named by MESHERY_CREDENTIAL_SECRET_NAME/_NAMESPACE, or, failing both,
+// the adapter's own config provider.
+//
+// http.DefaultClient is the only injection point available here:
+// adapter.RegisterMeshModelComponents and adapter.CreateComponents are
+// vendored meshery-adapter-library calls that take no *http.Client
+// parameter, so there is nowhere else to thread this credential through.
+// Call sites that talk to a different trust domain - see
+// validateGenerationURL's componentGenerationHTTPClient - must not reuse
+// http.DefaultClient for exactly that reason.
+func authenticateOutboundCalls(cfg configprovider.Handler, log logger.Handler) error {
+	credFile := os.Getenv("MESHERY_CREDENTIAL_FILE")
+	secretName := os.Getenv("MESHERY_CREDENTIAL_SECRET_NAME")
+	secretNamespace := os.Getenv("MESHERY_CREDENTIAL_SECRET_NAMESPACE")
+
+	var cred *credentials.Credential
+	var err error
+	switch {
+	case credFile != "":
+		cred, err = credentials.FromFile(credFile)
+	case secretName != "":
+		var kubeconfig []byte
+		if kubeconfig, err = readKubeconfig(); err == nil {
+			cred, err = credentials.FromKubernetesSecretUsingKubeconfig(context.Background(), kubeconfig, secretNamespace, secretName)
+		}
+	default:
+		cred, err = credentials.FromConfig(cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	rt, err := credentials.NewRoundTripper(cred, http.DefaultTransport)
+	if err != nil {
+		return err
+	}
+	http.DefaultClient.Transport = rt
+
+	if credFile != "" {
+		if _, err := credentials.WatchFile(credFile, rt, log); err != nil {
+			log.Warn(err)
+		}
+	}
+
+	return nil
+}
+
+// registerEdgeCapabilities announces the adapter's edge capability set
+// locally instead of registering workload components with a Meshery
+// Server, since edge mode runs standalone.
+func registerEdgeCapabilities(log logger.Handler) {
+	log.Info("Running in edge mode: serving the Nighthawk edge ingress at ", edgeAddr(), " without a Meshery Server")
+}
+
 func mesheryServerAddress() string {
 	meshReg := os.Getenv("MESHERY_SERVER")
 
@@ -143,28 +297,125 @@ func serviceAddress() string {
 	return "localhost"
 }
 
-func registerCapabilities(port string, log logger.Handler) {
-	// Register workloads
+func registerCapabilities(port string, log logger.Handler, tracker *registry.AttemptTracker) {
+	err := doRegisterStaticComponents(port, log)
+	if err != nil {
+		if terr := tracker.RecordFailure(staticComponentsLedgerKey, err); terr != nil {
+			log.Error(terr)
+		}
+		return
+	}
+
+	if terr := tracker.RecordSuccess(staticComponentsLedgerKey); terr != nil {
+		log.Error(terr)
+	}
+}
+
+// doRegisterStaticComponents is the pure registration step behind
+// registerCapabilities, with no ledger side effects, so the retrier in
+// registerCapabilitiesDynamically can call it directly and let
+// registry.Retrier.Run record the outcome exactly once.
+func doRegisterStaticComponents(port string, log logger.Handler) error {
+	ensureNighthawkAdapterConfigCRD(log)
+
 	log.Info("Registering static workloads with Meshery Server...")
 	if err := adapter.RegisterMeshModelComponents(instanceID, mesheryServerAddress(), serviceAddress(), port); err != nil {
 		log.Error(err)
+		return err
 	}
 
 	log.Info("Successfully registered static components with Meshery Server.")
+	return nil
+}
+
+// ensureNighthawkAdapterConfigCRD applies the NighthawkAdapterConfig CRD
+// schema alongside static component registration, so Meshery Server's
+// cluster has it without requiring a separate manual install step. It is
+// best-effort: a failure here only means ConfigController has nothing to
+// watch, not that static component registration should fail too.
+func ensureNighthawkAdapterConfigCRD(log logger.Handler) {
+	kubeconfig, err := readKubeconfig()
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+
+	if err := nighthawk.EnsureCRD(kubeconfig); err != nil {
+		log.Warn(err)
+	}
+}
+
+// staticComponentsLedgerKey and workloadsLedgerKey key the attempt ledger.
+// Registration today happens per batch rather than per component, so these
+// identify the two registration steps the adapter performs.
+const (
+	staticComponentsLedgerKey = "static-components"
+	workloadsLedgerKey        = "workloads"
+)
+
+// registrationBackoff bounds the delay between retries of failed
+// registrations, replacing the old fixed 24h ticker so operators see
+// failures retried quickly at first and then increasingly rarely.
+var registrationBackoff = registry.BackoffSchedule{
+	Base: time.Minute,
+	Max:  24 * time.Hour,
 }
 
-func registerCapabilitiesDynamically(port string, log logger.Handler) {
-	registerWorkloads(port, log)
-	//Start the ticker
-	const reRegisterAfter = 24
-	ticker := time.NewTicker(reRegisterAfter * time.Hour)
+func registerCapabilitiesDynamically(port string, log logger.Handler, tracker *registry.AttemptTracker) {
+	registerWorkloads(port, log, tracker)
+
+	retrier := registry.Retrier{Tracker: tracker, Schedule: registrationBackoff}
+	dispatch := func(component string) error {
+		switch component {
+		case staticComponentsLedgerKey:
+			return doRegisterStaticComponents(port, log)
+		case workloadsLedgerKey:
+			return doRegisterWorkloads(port, log)
+		default:
+			return nil
+		}
+	}
+
 	for {
-		<-ticker.C
-		registerWorkloads(port, log)
+		// RetryableFailed excludes components that have exhausted their
+		// max-attempt cap, so a permanently-failed component can't keep
+		// this loop from ever falling back to the re-registration cadence
+		// below.
+		failed := tracker.RetryableFailed()
+		if len(failed) == 0 {
+			// Nothing retryable outstanding; fall back to the configured
+			// cadence to pick up new versions from build.LatestVersion.
+			// NighthawkAdapterConfig's spec.reRegistrationIntervalSeconds
+			// overrides the built-in 24h default without a restart.
+			time.Sleep(nighthawk.CurrentAdapterConfig().ReRegistrationInterval)
+			registerWorkloads(port, log, tracker)
+			continue
+		}
+
+		retrier.Run(failed, dispatch, nil)
+	}
+}
+
+func registerWorkloads(port string, log logger.Handler, tracker *registry.AttemptTracker) error {
+	err := doRegisterWorkloads(port, log)
+	if err != nil {
+		if terr := tracker.RecordFailure(workloadsLedgerKey, err); terr != nil {
+			log.Error(terr)
+		}
+		return err
 	}
+
+	if terr := tracker.RecordSuccess(workloadsLedgerKey); terr != nil {
+		log.Error(terr)
+	}
+	return nil
 }
 
-func registerWorkloads(port string, log logger.Handler) {
+// doRegisterWorkloads is the pure registration step behind
+// registerWorkloads, with no ledger side effects, so the retrier in
+// registerCapabilitiesDynamically can call it directly and let
+// registry.Retrier.Run record the outcome exactly once.
+func doRegisterWorkloads(port string, log logger.Handler) error {
 	log.Info("Registering latest components with Meshery Server")
 
 	//First we create and store any new components if available
@@ -174,13 +425,35 @@ func registerWorkloads(port string, log logger.Handler) {
 	// Prechecking to skip comp gen
 	if os.Getenv("FORCE_DYNAMIC_REG") != "true" && adapter.AvailableVersions[version] {
 		log.Info("Components available statically for version ", version, ". Skipping dynamic component registeration")
-		return
+		return nil
 	}
+
+	// NighthawkAdapterConfig's spec.componentGeneration overrides the
+	// build-time defaults without a restart; COMP_GEN_URL/COMP_GEN_METHOD
+	// below take precedence over it for an explicit operator override.
+	if cfg := nighthawk.CurrentAdapterConfig(); cfg.ComponentGenerationURL != "" {
+		url = cfg.ComponentGenerationURL
+		if cfg.ComponentGenerationMethod != "" {
+			gm = cfg.ComponentGenerationMethod
+		}
+		if err := validateGenerationURL(url); err != nil {
+			log.Info("Failed to fetch or parse component generation payload from url ", url)
+			log.Error(err)
+			return err
+		}
+		log.Info("Registering workload components from NighthawkAdapterConfig url ", url, " using ", gm, " method...")
+	}
+
 	//If a URL is passed from env variable, it will be used for component generation with default method being "using manifests"
 	// In case a helm chart URL is passed, COMP_GEN_METHOD env variable should be set to Helm otherwise the component generation fails
 	if os.Getenv("COMP_GEN_URL") != "" && (os.Getenv("COMP_GEN_METHOD") == "Helm" || os.Getenv("COMP_GEN_METHOD") == "Manifest") {
 		url = os.Getenv("COMP_GEN_URL")
 		gm = os.Getenv("COMP_GEN_METHOD")
+		if err := validateGenerationURL(url); err != nil {
+			log.Info("Failed to fetch or parse component generation payload from url ", url)
+			log.Error(err)
+			return err
+		}
 		log.Info("Registering workload components from url ", url, " using ", gm, " method...")
 	}
 
@@ -197,7 +470,7 @@ func registerWorkloads(port string, log logger.Handler) {
 	if err != nil {
 		log.Info("Failed to generate components for version " + version)
 		log.Error(err)
-		return
+		return err
 	}
 
 	//The below log is checked in the workflows. If you change this log, reflect that change in the workflow where components are generated
@@ -208,7 +481,69 @@ func registerWorkloads(port string, log logger.Handler) {
 
 	if err := adapter.RegisterMeshModelComponents(instanceID, mesheryServerAddress(), serviceAddress(), port); err != nil {
 		log.Error(err)
-		return
+		return err
 	}
 	log.Info("Latest workload components successfully registered for version ", version)
+	return nil
+}
+
+// componentGenerationHTTPClient fetches COMP_GEN_URL payloads. COMP_GEN_URL
+// points at an operator-supplied Helm/manifest host, a different trust
+// domain than Meshery Server, so this must stay a plain client and never
+// become (or reuse) http.DefaultClient once authenticateOutboundCalls has
+// installed the Meshery Server credential on it.
+var componentGenerationHTTPClient = &http.Client{Transport: http.DefaultTransport}
+
+// validateGenerationURL fetches the COMP_GEN_URL payload and confirms it
+// parses as either JSON or YAML before handing the URL off to
+// adapter.CreateComponents, so a malformed component-generation response
+// surfaces as a clear error here instead of a confusing failure deep inside
+// the adapter library.
+func validateGenerationURL(url string) error {
+	resp, err := componentGenerationHTTPClient.Get(url) //nolint:gosec
+	if err != nil {
+		return registry.ErrFetchGenerationPayload(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return registry.ErrFetchGenerationPayload(err)
+	}
+
+	var payload any
+	if err := config.Unmarshal(body, &payload); err != nil {
+		return registry.ErrFetchGenerationPayload(err)
+	}
+
+	return nil
+}
+
+// serveRegistryDump exposes the attempt ledger over HTTP so mesheryctl and
+// CI workflows can fail fast when registration is unhealthy, without
+// having to tail adapter logs.
+//
+// This is an HTTP side-channel, not an RPC on the adapter's own gRPC
+// service: adapter.Handler/grpc.Service are vendored meshery-adapter-library
+// types, and this tree never had their implementation (nighthawk.New was
+// already called from main.go at baseline with no nighthawk package behind
+// it), so there is no protobuf-generated service definition here to add a
+// new RPC method to. To keep the ledger discoverable through the adapter's
+// real gRPC surface rather than only this separate port, serveRegistryDump
+// announces its address over ev, the same events.EventStreamer the gRPC
+// service already streams to Meshery Server/mesheryctl as service.EventStreamer.
+func serveRegistryDump(tracker *registry.AttemptTracker, log logger.Handler, ev *events.EventStreamer) {
+	mux := http.NewServeMux()
+	mux.Handle("/registry/attempts", tracker.DumpHandler())
+
+	addr := registryDumpAddr()
+	log.Info("Registry attempt ledger available at ", addr, "/registry/attempts")
+	ev.Publish(&events.Event{
+		Summary:  "Registry attempt ledger available at " + addr + "/registry/attempts",
+		Category: "registry",
+	})
+
+	if err := http.ListenAndServe(addr, mux); err != nil { //nolint:gosec
+		log.Error(err)
+	}
 }
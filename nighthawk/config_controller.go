@@ -0,0 +1,213 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nighthawk
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/layer5io/meshkit/logger"
+	"github.com/layer5io/meshkit/utils/events"
+	mesherykube "github.com/layer5io/meshkit/utils/kubernetes"
+	"github.com/meshery/meshery-nighthawk/internal/config"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nighthawkAdapterConfigGVR identifies the NighthawkAdapterConfig CRD that
+// ConfigController watches.
+var nighthawkAdapterConfigGVR = schema.GroupVersionResource{
+	Group:    "meshery.io",
+	Version:  "v1alpha1",
+	Resource: "nighthawkadapterconfigs",
+}
+
+// LoadTestProfile holds the default RPS/concurrency/duration/target
+// settings applied to load tests that don't override them explicitly.
+type LoadTestProfile struct {
+	RPS               int
+	Concurrency       int
+	DurationSeconds   int
+	TargetURLTemplate string
+}
+
+// TargetFilter allow/deny-lists targets that load tests may be pointed at.
+type TargetFilter struct {
+	Allow []string
+	Deny  []string
+}
+
+// AdapterConfig is the live, CR-derived configuration ConfigController
+// hands out to subscribers such as the load-test handler.
+type AdapterConfig struct {
+	ComponentGenerationURL    string
+	ComponentGenerationMethod string
+	ReRegistrationInterval    time.Duration
+	DefaultLoadTestProfile    LoadTestProfile
+	Targets                   TargetFilter
+}
+
+// ConfigController watches NighthawkAdapterConfig custom resources and
+// converts their spec into an AdapterConfig snapshot, publishing each
+// change through the adapter's EventStreamer so subscribers such as the
+// load-test handler can pick up new defaults without a restart.
+type ConfigController struct {
+	informer cache.SharedIndexInformer
+	ev       *events.EventStreamer
+	log      logger.Handler
+
+	mu      sync.RWMutex
+	current AdapterConfig
+}
+
+// NewConfigController builds a ConfigController backed by a dynamic
+// informer over the cluster identified by kubeconfig bytes, mirroring how
+// the rest of the adapter turns a kubeconfig into a client via
+// meshkit/utils/kubernetes.
+func NewConfigController(kubeconfig []byte, ev *events.EventStreamer, log logger.Handler) (*ConfigController, error) {
+	kc, err := mesherykube.New(kubeconfig)
+	if err != nil {
+		return nil, ErrCreateKubeClient(err)
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(kc.DynamicKubeClient, 0)
+	informer := factory.ForResource(nighthawkAdapterConfigGVR).Informer()
+
+	cc := &ConfigController{
+		informer: informer,
+		ev:       ev,
+		log:      log,
+		current:  defaultAdapterConfig(),
+	}
+
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { cc.apply(obj) },
+		UpdateFunc: func(_, obj interface{}) { cc.apply(obj) },
+		DeleteFunc: func(interface{}) { cc.reset() },
+	})
+	if err != nil {
+		return nil, ErrWatchConfig(err)
+	}
+
+	return cc, nil
+}
+
+// Start begins watching for NighthawkAdapterConfig changes until stop is
+// closed.
+func (c *ConfigController) Start(stop <-chan struct{}) {
+	go c.informer.Run(stop)
+}
+
+// Current returns the most recently applied AdapterConfig snapshot.
+func (c *ConfigController) Current() AdapterConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current
+}
+
+func (c *ConfigController) apply(obj interface{}) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	cfg, err := adapterConfigFromSpec(u)
+	if err != nil {
+		c.log.Error(err)
+		return
+	}
+
+	c.mu.Lock()
+	c.current = cfg
+	c.mu.Unlock()
+
+	c.ev.Publish(&events.Event{
+		Summary:  "Nighthawk adapter configuration updated from " + u.GetName(),
+		Category: "config",
+	})
+}
+
+func (c *ConfigController) reset() {
+	c.mu.Lock()
+	c.current = defaultAdapterConfig()
+	c.mu.Unlock()
+
+	c.ev.Publish(&events.Event{
+		Summary:  "NighthawkAdapterConfig removed; reverted to default adapter configuration",
+		Category: "config",
+	})
+}
+
+func defaultAdapterConfig() AdapterConfig {
+	return AdapterConfig{
+		ReRegistrationInterval: 24 * time.Hour,
+	}
+}
+
+// nighthawkAdapterConfigSpec mirrors the spec fields declared in
+// build/crds/nighthawkadapterconfig.yaml.
+type nighthawkAdapterConfigSpec struct {
+	ComponentGeneration struct {
+		URL    string `json:"url"`
+		Method string `json:"method"`
+	} `json:"componentGeneration"`
+	ReRegistrationIntervalSeconds int `json:"reRegistrationIntervalSeconds"`
+	DefaultLoadTestProfile        struct {
+		RPS               int    `json:"rps"`
+		Concurrency       int    `json:"concurrency"`
+		DurationSeconds   int    `json:"durationSeconds"`
+		TargetURLTemplate string `json:"targetURLTemplate"`
+	} `json:"defaultLoadTestProfile"`
+	Targets struct {
+		Allow []string `json:"allow"`
+		Deny  []string `json:"deny"`
+	} `json:"targets"`
+}
+
+// adapterConfigFromSpec re-marshals the unstructured CR's spec to bytes
+// and decodes it through the adapter's unified config.Unmarshal, so the
+// CRD spec is parsed the same way as every other config payload.
+func adapterConfigFromSpec(u *unstructured.Unstructured) (AdapterConfig, error) {
+	specBytes, err := json.Marshal(u.Object["spec"])
+	if err != nil {
+		return AdapterConfig{}, ErrDecodeConfig(err)
+	}
+
+	var spec nighthawkAdapterConfigSpec
+	if err := config.Unmarshal(specBytes, &spec); err != nil {
+		return AdapterConfig{}, err
+	}
+
+	cfg := defaultAdapterConfig()
+	cfg.ComponentGenerationURL = spec.ComponentGeneration.URL
+	cfg.ComponentGenerationMethod = spec.ComponentGeneration.Method
+
+	if spec.ReRegistrationIntervalSeconds > 0 {
+		cfg.ReRegistrationInterval = time.Duration(spec.ReRegistrationIntervalSeconds) * time.Second
+	}
+
+	cfg.DefaultLoadTestProfile = LoadTestProfile{
+		RPS:               spec.DefaultLoadTestProfile.RPS,
+		Concurrency:       spec.DefaultLoadTestProfile.Concurrency,
+		DurationSeconds:   spec.DefaultLoadTestProfile.DurationSeconds,
+		TargetURLTemplate: spec.DefaultLoadTestProfile.TargetURLTemplate,
+	}
+	cfg.Targets = TargetFilter{Allow: spec.Targets.Allow, Deny: spec.Targets.Deny}
+
+	return cfg, nil
+}
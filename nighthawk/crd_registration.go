@@ -0,0 +1,71 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nighthawk
+
+import (
+	"context"
+
+	"github.com/meshery/meshery-nighthawk/build/crds"
+	"github.com/meshery/meshery-nighthawk/internal/config"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// EnsureCRD applies the embedded NighthawkAdapterConfig CRD schema
+// (build/crds/nighthawkadapterconfig.yaml) to the cluster identified by
+// kubeconfig, creating it if absent and updating it in place otherwise.
+// Callers register this alongside the adapter's static components so
+// Meshery Server's cluster always has the CRD ConfigController watches,
+// without a separate manual install step.
+func EnsureCRD(kubeconfig []byte) error {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return ErrCreateKubeClient(err)
+	}
+
+	client, err := apiextensionsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return ErrCreateKubeClient(err)
+	}
+
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := config.Unmarshal(crds.NighthawkAdapterConfig, &crd); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	defs := client.ApiextensionsV1().CustomResourceDefinitions()
+
+	existing, err := defs.Get(ctx, crd.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := defs.Create(ctx, &crd, metav1.CreateOptions{}); err != nil {
+			return ErrEnsureCRD(err)
+		}
+		return nil
+	}
+	if err != nil {
+		return ErrEnsureCRD(err)
+	}
+
+	crd.ResourceVersion = existing.ResourceVersion
+	if _, err := defs.Update(ctx, &crd, metav1.UpdateOptions{}); err != nil {
+		return ErrEnsureCRD(err)
+	}
+
+	return nil
+}
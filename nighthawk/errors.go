@@ -0,0 +1,98 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nighthawk
+
+import "github.com/layer5io/meshkit/errors"
+
+const (
+	ErrCreateKubeClientCode        = "nighthawk_configwatcher_1000"
+	ErrWatchConfigCode             = "nighthawk_configwatcher_1001"
+	ErrDecodeConfigCode            = "nighthawk_configwatcher_1002"
+	ErrEnsureCRDCode               = "nighthawk_configwatcher_1003"
+	ErrStartLoadTestCode           = "nighthawk_loadtest_1000"
+	ErrTargetNotAllowedCode        = "nighthawk_loadtest_1001"
+	ErrIncompleteTLSClientCertCode = "nighthawk_loadtest_1002"
+)
+
+// ErrCreateKubeClient is returned when ConfigController cannot build a
+// Kubernetes client from the adapter's kubeconfig.
+func ErrCreateKubeClient(err error) error {
+	return errors.New(ErrCreateKubeClientCode, errors.Alert,
+		[]string{"Failed to create a Kubernetes client for the NighthawkAdapterConfig watcher"},
+		[]string{err.Error()},
+		[]string{"The adapter's kubeconfig is missing or invalid"},
+		[]string{"Ensure Meshery has a valid kubeconfig configured for this adapter's cluster"})
+}
+
+// ErrWatchConfig is returned when ConfigController cannot register its
+// informer event handlers.
+func ErrWatchConfig(err error) error {
+	return errors.New(ErrWatchConfigCode, errors.Alert,
+		[]string{"Failed to watch NighthawkAdapterConfig resources"},
+		[]string{err.Error()},
+		[]string{"The informer could not be started, often due to the CRD not being installed in the cluster"},
+		[]string{"Apply build/crds/nighthawkadapterconfig.yaml to the target cluster and restart the adapter"})
+}
+
+// ErrDecodeConfig is returned when a NighthawkAdapterConfig's spec could
+// not be re-marshaled to bytes for config.Unmarshal.
+func ErrDecodeConfig(err error) error {
+	return errors.New(ErrDecodeConfigCode, errors.Alert,
+		[]string{"Failed to decode the NighthawkAdapterConfig spec"},
+		[]string{err.Error()},
+		[]string{"The custom resource's spec contains a value the Kubernetes API server accepted but that cannot be re-serialized"},
+		[]string{"Check the NighthawkAdapterConfig resource for fields outside the documented schema"})
+}
+
+// ErrEnsureCRD is returned when the embedded NighthawkAdapterConfig CRD
+// schema could not be created or updated in the cluster.
+func ErrEnsureCRD(err error) error {
+	return errors.New(ErrEnsureCRDCode, errors.Alert,
+		[]string{"Failed to apply the NighthawkAdapterConfig CRD to the cluster"},
+		[]string{err.Error()},
+		[]string{"The adapter's Kubernetes credentials lack permission to manage CustomResourceDefinitions, or the API server rejected the schema"},
+		[]string{"Grant the adapter's service account permission to create/update CustomResourceDefinitions, or apply build/crds/nighthawkadapterconfig.yaml manually"})
+}
+
+// ErrStartLoadTest is returned when the nighthawk_client process backing a
+// load test could not be started.
+func ErrStartLoadTest(err error) error {
+	return errors.New(ErrStartLoadTestCode, errors.Alert,
+		[]string{"Failed to start the Nighthawk load test"},
+		[]string{err.Error()},
+		[]string{"The nighthawk_client binary is missing from PATH or the test definition is invalid"},
+		[]string{"Verify nighthawk_client is installed in the adapter image and that the test definition is well-formed"})
+}
+
+// ErrTargetNotAllowed is returned when a load test's target fails the
+// allow/deny list enforced by the current NighthawkAdapterConfig.
+func ErrTargetNotAllowed(target string) error {
+	return errors.New(ErrTargetNotAllowedCode, errors.Alert,
+		[]string{"Target is not allowed by the current NighthawkAdapterConfig: " + target},
+		[]string{"the target matched a deny entry, or an allow list is configured and the target matched none of it"},
+		[]string{"The NighthawkAdapterConfig CR's spec.targets narrows which targets load tests may hit"},
+		[]string{"Add the target to spec.targets.allow, or remove it from spec.targets.deny"})
+}
+
+// ErrIncompleteTLSClientCert is returned when a load test's TLS settings
+// give only one of CertFile/KeyFile, which loadTestArgs cannot turn into a
+// usable client certificate for nighthawk_client.
+func ErrIncompleteTLSClientCert() error {
+	return errors.New(ErrIncompleteTLSClientCertCode, errors.Alert,
+		[]string{"TLS client certificate is incomplete"},
+		[]string{"only one of certFile/keyFile was given"},
+		[]string{"A client certificate requires both a certificate file and a matching private key file"},
+		[]string{"Set both tls.certFile and tls.keyFile, or neither"})
+}
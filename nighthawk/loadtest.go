@@ -0,0 +1,232 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nighthawk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// configControllerMu guards configController, read on every RunLoadTest
+// call and written once by SetConfigController at startup, so in-flight
+// and future tests pick up NighthawkAdapterConfig changes without a
+// restart.
+var (
+	configControllerMu sync.RWMutex
+	configController   *ConfigController
+)
+
+// SetConfigController registers the ConfigController RunLoadTest consults
+// for default load-test profiles and target allow/deny lists. Passing nil
+// reverts to built-in defaults with no target restrictions.
+func SetConfigController(cc *ConfigController) {
+	configControllerMu.Lock()
+	defer configControllerMu.Unlock()
+	configController = cc
+}
+
+func currentAdapterConfig() AdapterConfig {
+	configControllerMu.RLock()
+	cc := configController
+	configControllerMu.RUnlock()
+
+	if cc == nil {
+		return defaultAdapterConfig()
+	}
+
+	return cc.Current()
+}
+
+// CurrentAdapterConfig returns the same AdapterConfig snapshot RunLoadTest
+// consults, for callers outside this package - such as main's component
+// registration loop - that also need to honor the live
+// NighthawkAdapterConfig CR.
+func CurrentAdapterConfig() AdapterConfig {
+	return currentAdapterConfig()
+}
+
+// TLSSettings configures the TLS behavior nighthawk_client uses when
+// hitting a target.
+type TLSSettings struct {
+	InsecureSkipVerify bool
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+}
+
+// LoadTestRequest describes a single Nighthawk load test, whether it came
+// from the gRPC ApplyOperation path or the edge-mode HTTP ingress.
+type LoadTestRequest struct {
+	Target      string
+	RPS         int
+	Concurrency int
+	Duration    time.Duration
+	Headers     map[string]string
+	TLS         *TLSSettings
+}
+
+// LoadTestResult is one incremental line of nighthawk_client output,
+// forwarded to the caller as it streams in.
+type LoadTestResult struct {
+	Line string
+	Done bool
+	Err  error
+}
+
+// RunLoadTest shells out to the nighthawk_client binary this adapter
+// bundles and streams its stdout back line by line until the process
+// exits or ctx is cancelled. It applies the current NighthawkAdapterConfig
+// defaults to unset fields and rejects targets the config's allow/deny
+// lists disallow, so in-flight and future tests honor the latest CR
+// without restarting the adapter.
+func RunLoadTest(ctx context.Context, req LoadTestRequest) (<-chan LoadTestResult, error) {
+	cfg := currentAdapterConfig()
+	req = applyConfigDefaults(req, cfg)
+
+	if err := enforceTargetFilter(cfg.Targets, req.Target); err != nil {
+		return nil, err
+	}
+
+	if err := validateTLSSettings(req.TLS); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "nighthawk_client", loadTestArgs(req)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, ErrStartLoadTest(err)
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return nil, ErrStartLoadTest(err)
+	}
+
+	results := make(chan LoadTestResult)
+	go func() {
+		defer close(results)
+
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			results <- LoadTestResult{Line: scanner.Text()}
+		}
+
+		if err := scanner.Err(); err != nil {
+			results <- LoadTestResult{Err: err}
+		}
+
+		if err := cmd.Wait(); err != nil {
+			results <- LoadTestResult{Err: err}
+			return
+		}
+
+		results <- LoadTestResult{Done: true}
+	}()
+
+	return results, nil
+}
+
+// applyConfigDefaults fills any unset request fields from the current
+// NighthawkAdapterConfig's default load-test profile.
+func applyConfigDefaults(req LoadTestRequest, cfg AdapterConfig) LoadTestRequest {
+	profile := cfg.DefaultLoadTestProfile
+
+	if req.Target == "" {
+		req.Target = profile.TargetURLTemplate
+	}
+	if req.RPS == 0 {
+		req.RPS = profile.RPS
+	}
+	if req.Concurrency == 0 {
+		req.Concurrency = profile.Concurrency
+	}
+	if req.Duration == 0 && profile.DurationSeconds > 0 {
+		req.Duration = time.Duration(profile.DurationSeconds) * time.Second
+	}
+
+	return req
+}
+
+// enforceTargetFilter rejects a target that matches a deny entry, or that
+// fails to match any entry when an allow list is configured.
+func enforceTargetFilter(filter TargetFilter, target string) error {
+	for _, denied := range filter.Deny {
+		if denied == target {
+			return ErrTargetNotAllowed(target)
+		}
+	}
+
+	if len(filter.Allow) == 0 {
+		return nil
+	}
+
+	for _, allowed := range filter.Allow {
+		if allowed == target {
+			return nil
+		}
+	}
+
+	return ErrTargetNotAllowed(target)
+}
+
+// validateTLSSettings rejects a half-specified client certificate instead
+// of silently downgrading the load test to no client cert: CertFile and
+// KeyFile must be given together or not at all.
+func validateTLSSettings(tls *TLSSettings) error {
+	if tls == nil {
+		return nil
+	}
+
+	if (tls.CertFile == "") != (tls.KeyFile == "") {
+		return ErrIncompleteTLSClientCert()
+	}
+
+	return nil
+}
+
+func loadTestArgs(req LoadTestRequest) []string {
+	args := []string{
+		"--rps", strconv.Itoa(req.RPS),
+		"--concurrency", strconv.Itoa(req.Concurrency),
+		"--duration", strconv.Itoa(int(req.Duration.Seconds())),
+	}
+
+	for key, value := range req.Headers {
+		args = append(args, "--request-header", fmt.Sprintf("%s:%s", key, value))
+	}
+
+	if req.TLS != nil {
+		if req.TLS.InsecureSkipVerify {
+			args = append(args, "--trust-all-certificates")
+		}
+		if req.TLS.CAFile != "" {
+			args = append(args, "--ca-cert-path", req.TLS.CAFile)
+		}
+		if req.TLS.CertFile != "" && req.TLS.KeyFile != "" {
+			args = append(args, "--client-cert-path", req.TLS.CertFile)
+			args = append(args, "--client-key-path", req.TLS.KeyFile)
+		}
+	}
+
+	args = append(args, req.Target)
+
+	return args
+}
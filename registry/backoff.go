@@ -0,0 +1,85 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import "time"
+
+// BackoffSchedule computes the exponentially increasing delay between
+// registration retries, capped at max. attempt is 1-indexed (the delay
+// before the first retry, i.e. attempt 1, is base).
+type BackoffSchedule struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Delay returns the delay to wait before retrying the given attempt
+// number.
+func (b BackoffSchedule) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := b.Base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= b.Max {
+			return b.Max
+		}
+	}
+
+	return delay
+}
+
+// Retrier retries a registration func per-component on an exponential
+// backoff until it succeeds or the tracker reports the component
+// exhausted.
+type Retrier struct {
+	Tracker  *AttemptTracker
+	Schedule BackoffSchedule
+}
+
+// Run retries register(component) for every entry in components until it
+// succeeds, the component is exhausted, or stop is closed.
+func (r *Retrier) Run(components []string, register func(component string) error, stop <-chan struct{}) {
+	pending := make([]string, len(components))
+	copy(pending, components)
+
+	attempt := 1
+	for len(pending) > 0 {
+		select {
+		case <-stop:
+			return
+		case <-time.After(r.Schedule.Delay(attempt)):
+		}
+
+		var stillFailing []string
+		for _, component := range pending {
+			if r.Tracker.Exhausted(component) {
+				continue
+			}
+
+			if err := register(component); err != nil {
+				_ = r.Tracker.RecordFailure(component, err)
+				stillFailing = append(stillFailing, component)
+				continue
+			}
+
+			_ = r.Tracker.RecordSuccess(component)
+		}
+
+		pending = stillFailing
+		attempt++
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffScheduleDelay(t *testing.T) {
+	schedule := BackoffSchedule{Base: time.Second, Max: 30 * time.Second}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 0, want: time.Second},
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 16 * time.Second},
+		{attempt: 6, want: 30 * time.Second},
+		{attempt: 20, want: 30 * time.Second},
+	}
+
+	for _, c := range cases {
+		got := schedule.Delay(c.attempt)
+		if got != c.want {
+			t.Errorf("Delay(%d) = %s, want %s", c.attempt, got, c.want)
+		}
+	}
+}
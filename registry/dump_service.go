@@ -0,0 +1,50 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DumpResponse is the payload served by the ledger dump endpoint.
+// Errors lists the components whose last recorded attempt failed, so
+// mesheryctl / CI workflows can fail the run on a non-empty list without
+// having to interpret the full ledger.
+type DumpResponse struct {
+	Components map[string]*Attempt `json:"components"`
+	Errors     []string            `json:"errors"`
+}
+
+// DumpHandler serves the current attempt ledger as JSON. It responds with
+// HTTP 503 when one or more components have a failing last attempt, so
+// callers can treat a non-2xx response as "registration is unhealthy"
+// without parsing the body.
+func (t *AttemptTracker) DumpHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ledger := t.Dump()
+		resp := DumpResponse{
+			Components: ledger.Components,
+			Errors:     t.Failed(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(resp.Errors) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import "github.com/layer5io/meshkit/errors"
+
+const (
+	ErrCreateLedgerDirCode        = "nighthawk_registry_1000"
+	ErrReadLedgerCode             = "nighthawk_registry_1001"
+	ErrUnmarshalLedgerCode        = "nighthawk_registry_1002"
+	ErrMarshalLedgerCode          = "nighthawk_registry_1003"
+	ErrWriteLedgerCode            = "nighthawk_registry_1004"
+	ErrFetchGenerationPayloadCode = "nighthawk_registry_1005"
+)
+
+// ErrCreateLedgerDir is returned when the registry ledger directory could
+// not be created under the adapter's config root.
+func ErrCreateLedgerDir(err error) error {
+	return errors.New(ErrCreateLedgerDirCode, errors.Alert,
+		[]string{"Failed to create the registry attempt ledger directory"},
+		[]string{err.Error()},
+		[]string{"The adapter's config root path is not writable"},
+		[]string{"Ensure the adapter process has write permission to its config root path"})
+}
+
+// ErrReadLedger is returned when the on-disk attempt ledger could not be
+// read.
+func ErrReadLedger(err error) error {
+	return errors.New(ErrReadLedgerCode, errors.Alert,
+		[]string{"Failed to read the registry attempt ledger"},
+		[]string{err.Error()},
+		[]string{"The ledger file is missing required read permissions or is corrupted"},
+		[]string{"Check permissions on the ledger file and restore it from a backup if corrupted"})
+}
+
+// ErrUnmarshalLedger is returned when the on-disk attempt ledger contains
+// invalid JSON.
+func ErrUnmarshalLedger(err error) error {
+	return errors.New(ErrUnmarshalLedgerCode, errors.Alert,
+		[]string{"Failed to parse the registry attempt ledger"},
+		[]string{err.Error()},
+		[]string{"The ledger file was modified outside of the adapter and is no longer valid JSON"},
+		[]string{"Remove or fix the ledger file so the adapter can recreate it"})
+}
+
+// ErrMarshalLedger is returned when the in-memory attempt ledger could not
+// be serialized to JSON.
+func ErrMarshalLedger(err error) error {
+	return errors.New(ErrMarshalLedgerCode, errors.Alert,
+		[]string{"Failed to serialize the registry attempt ledger"},
+		[]string{err.Error()},
+		[]string{"The ledger contains a value that cannot be represented as JSON"},
+		[]string{"Report this issue along with the component names being registered"})
+}
+
+// ErrWriteLedger is returned when the attempt ledger could not be written
+// to disk.
+func ErrWriteLedger(err error) error {
+	return errors.New(ErrWriteLedgerCode, errors.Alert,
+		[]string{"Failed to persist the registry attempt ledger"},
+		[]string{err.Error()},
+		[]string{"The adapter's config root path is not writable or is out of disk space"},
+		[]string{"Ensure the adapter process has write permission and free disk space on its config root path"})
+}
+
+// ErrFetchGenerationPayload is returned when the COMP_GEN_URL payload could
+// not be fetched, or fetched but parsed as neither JSON nor YAML.
+func ErrFetchGenerationPayload(err error) error {
+	return errors.New(ErrFetchGenerationPayloadCode, errors.Alert,
+		[]string{"Failed to fetch or parse the component generation payload"},
+		[]string{err.Error()},
+		[]string{"COMP_GEN_URL is unreachable, or returned a response that is not valid JSON or YAML"},
+		[]string{"Verify COMP_GEN_URL is reachable from the adapter and returns a well-formed Helm/manifest generation response"})
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry persists component registration attempts so operators
+// can see which Nighthawk components failed to register, why, and how many
+// times registration was retried.
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/layer5io/meshkit/errors"
+)
+
+// ledgerFileName is the name of the JSON ledger written under
+// <rootPath>/logs/registry/, mirroring Meshery server's register_attempts.json.
+const ledgerFileName = "register_attempts.json"
+
+// Attempt records the outcome of a single registration attempt for a
+// component.
+type Attempt struct {
+	Count                int       `json:"count"`
+	LastAttemptedAt      time.Time `json:"last_attempted_at"`
+	LastErrorCode        string    `json:"last_error_code,omitempty"`
+	LastErrorSeverity    string    `json:"last_error_severity,omitempty"`
+	LastErrorShort       string    `json:"last_error_short,omitempty"`
+	LastErrorLong        string    `json:"last_error_long,omitempty"`
+	ProbableCause        string    `json:"probable_cause,omitempty"`
+	SuggestedRemediation string    `json:"suggested_remediation,omitempty"`
+	Succeeded            bool      `json:"succeeded"`
+}
+
+// Ledger is the on-disk shape of the attempt tracker: a map of component
+// name to its latest attempt record.
+type Ledger struct {
+	Components map[string]*Attempt `json:"components"`
+}
+
+// AttemptTracker persists per-component registration attempts to a JSON
+// ledger and exposes the subset of components that still need to be
+// retried.
+type AttemptTracker struct {
+	mu         sync.Mutex
+	path       string
+	maxAttempt int
+	ledger     *Ledger
+}
+
+// NewAttemptTracker creates an AttemptTracker backed by a ledger file under
+// <rootPath>/logs/registry/. maxAttempt bounds how many times a failed
+// component is retried before it is reported as permanently failed; a
+// value of 0 means unlimited retries.
+func NewAttemptTracker(rootPath string, maxAttempt int) (*AttemptTracker, error) {
+	dir := filepath.Join(rootPath, "logs", "registry")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, ErrCreateLedgerDir(err)
+	}
+
+	t := &AttemptTracker{
+		path:       filepath.Join(dir, ledgerFileName),
+		maxAttempt: maxAttempt,
+		ledger:     &Ledger{Components: map[string]*Attempt{}},
+	}
+
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *AttemptTracker) load() error {
+	data, err := os.ReadFile(t.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return ErrReadLedger(err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, t.ledger); err != nil {
+		return ErrUnmarshalLedger(err)
+	}
+
+	return nil
+}
+
+func (t *AttemptTracker) persist() error {
+	data, err := json.MarshalIndent(t.ledger, "", "  ")
+	if err != nil {
+		return ErrMarshalLedger(err)
+	}
+
+	if err := os.WriteFile(t.path, data, 0640); err != nil {
+		return ErrWriteLedger(err)
+	}
+
+	return nil
+}
+
+// RecordSuccess marks component as successfully registered, resetting its
+// attempt count.
+func (t *AttemptTracker) RecordSuccess(component string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ledger.Components[component] = &Attempt{
+		Count:           0,
+		LastAttemptedAt: time.Now(),
+		Succeeded:       true,
+	}
+
+	return t.persist()
+}
+
+// RecordFailure records a failed registration attempt for component,
+// extracting structured fields from err when it is a meshkit
+// *errors.Error.
+func (t *AttemptTracker) RecordFailure(component string, err error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attempt := t.ledger.Components[component]
+	if attempt == nil {
+		attempt = &Attempt{}
+		t.ledger.Components[component] = attempt
+	}
+
+	attempt.Count++
+	attempt.LastAttemptedAt = time.Now()
+	attempt.Succeeded = false
+
+	if merr, ok := err.(*errors.Error); ok {
+		attempt.LastErrorCode = merr.Code
+		attempt.LastErrorSeverity = merr.Severity.String()
+		attempt.LastErrorShort = joinLines(merr.ShortDescription)
+		attempt.LastErrorLong = joinLines(merr.LongDescription)
+		attempt.ProbableCause = joinLines(merr.ProbableCause)
+		attempt.SuggestedRemediation = joinLines(merr.SuggestedRemediation)
+	} else {
+		attempt.LastErrorShort = err.Error()
+	}
+
+	return t.persist()
+}
+
+// Exhausted reports whether component has hit the configured max-attempt
+// cap and should stop being retried. It always returns false when
+// maxAttempt is 0 (unlimited).
+func (t *AttemptTracker) Exhausted(component string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.maxAttempt == 0 {
+		return false
+	}
+
+	attempt := t.ledger.Components[component]
+	return attempt != nil && attempt.Count >= t.maxAttempt
+}
+
+// Failed returns the names of components whose last recorded attempt did
+// not succeed, including components that have exhausted their max
+// attempts. Use RetryableFailed to drive retry loops.
+func (t *AttemptTracker) Failed() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var failed []string
+	for name, attempt := range t.ledger.Components {
+		if !attempt.Succeeded {
+			failed = append(failed, name)
+		}
+	}
+
+	return failed
+}
+
+// RetryableFailed returns the names of components whose last recorded
+// attempt did not succeed and that have not yet exhausted their
+// max-attempt cap. Callers driving a retry loop should use this instead
+// of Failed, which also includes permanently-failed components that would
+// otherwise keep the loop busy forever.
+func (t *AttemptTracker) RetryableFailed() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var failed []string
+	for name, attempt := range t.ledger.Components {
+		if attempt.Succeeded {
+			continue
+		}
+		if t.maxAttempt > 0 && attempt.Count >= t.maxAttempt {
+			continue
+		}
+		failed = append(failed, name)
+	}
+
+	return failed
+}
+
+// Dump returns a deep-enough copy of the ledger for serialization, e.g. by
+// the registry gRPC dump endpoint.
+func (t *AttemptTracker) Dump() *Ledger {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	components := make(map[string]*Attempt, len(t.ledger.Components))
+	for name, attempt := range t.ledger.Components {
+		a := *attempt
+		components[name] = &a
+	}
+
+	return &Ledger{Components: components}
+}
+
+func joinLines(lines []string) string {
+	out := ""
+	for i, line := range lines {
+		if i > 0 {
+			out += " "
+		}
+		out += line
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Meshery Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttemptTrackerRecordAndPersist(t *testing.T) {
+	rootPath := t.TempDir()
+
+	tracker, err := NewAttemptTracker(rootPath, 2)
+	if err != nil {
+		t.Fatalf("NewAttemptTracker() error = %v", err)
+	}
+
+	if err := tracker.RecordFailure("workloads", errors.New("boom")); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	reloaded, err := NewAttemptTracker(rootPath, 2)
+	if err != nil {
+		t.Fatalf("NewAttemptTracker() reload error = %v", err)
+	}
+
+	failed := reloaded.Failed()
+	if len(failed) != 1 || failed[0] != "workloads" {
+		t.Fatalf("Failed() after reload = %v, want [workloads]", failed)
+	}
+
+	if err := reloaded.RecordSuccess("workloads"); err != nil {
+		t.Fatalf("RecordSuccess() error = %v", err)
+	}
+	if failed := reloaded.Failed(); len(failed) != 0 {
+		t.Fatalf("Failed() after success = %v, want none", failed)
+	}
+}
+
+func TestAttemptTrackerExhausted(t *testing.T) {
+	tracker, err := NewAttemptTracker(t.TempDir(), 2)
+	if err != nil {
+		t.Fatalf("NewAttemptTracker() error = %v", err)
+	}
+
+	if tracker.Exhausted("workloads") {
+		t.Fatal("Exhausted() = true before any attempts, want false")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := tracker.RecordFailure("workloads", errors.New("boom")); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+
+	if !tracker.Exhausted("workloads") {
+		t.Fatal("Exhausted() = false after maxAttempt failures, want true")
+	}
+}
+
+func TestAttemptTrackerExhaustedUnlimited(t *testing.T) {
+	tracker, err := NewAttemptTracker(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewAttemptTracker() error = %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := tracker.RecordFailure("workloads", errors.New("boom")); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+
+	if tracker.Exhausted("workloads") {
+		t.Fatal("Exhausted() = true with maxAttempt 0, want false (unlimited retries)")
+	}
+}
+
+func TestAttemptTrackerRetryableFailed(t *testing.T) {
+	tracker, err := NewAttemptTracker(t.TempDir(), 2)
+	if err != nil {
+		t.Fatalf("NewAttemptTracker() error = %v", err)
+	}
+
+	// "exhausted" hits maxAttempt (2); "retryable" has only failed once.
+	for i := 0; i < 2; i++ {
+		if err := tracker.RecordFailure("exhausted", errors.New("boom")); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+	if err := tracker.RecordFailure("retryable", errors.New("boom")); err != nil {
+		t.Fatalf("RecordFailure() error = %v", err)
+	}
+
+	all := tracker.Failed()
+	if len(all) != 2 {
+		t.Fatalf("Failed() = %v, want both components", all)
+	}
+
+	retryable := tracker.RetryableFailed()
+	if len(retryable) != 1 || retryable[0] != "retryable" {
+		t.Fatalf("RetryableFailed() = %v, want [retryable]", retryable)
+	}
+}